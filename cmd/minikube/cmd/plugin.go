@@ -0,0 +1,76 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/plugin"
+)
+
+// PluginCmd manages minikube-* plugin binaries discovered on $PATH.
+var PluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "List and manage minikube plugins",
+	Long:  `plugin discovers executables named "minikube-*" on $PATH, each of which can be invoked as a minikube subcommand.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List discovered plugins",
+	Run: func(cmd *cobra.Command, args []string) {
+		plugins, err := plugin.Discover()
+		if err != nil {
+			klog.Exitf("Error discovering plugins: %v", err)
+		}
+		if out.GetFormat() == out.JSON || out.GetFormat() == out.YAML {
+			if err := out.Data(plugins); err != nil {
+				klog.Exitf("Error printing plugins: %v", err)
+			}
+			return
+		}
+		if len(plugins) == 0 {
+			out.String("No plugins found on $PATH\n")
+			return
+		}
+		// text and wide share this rendering: there are no extra plugin
+		// columns wide would add beyond name and path.
+		for _, p := range plugins {
+			if isShadowed(p.Name) {
+				out.Warning("%s\tshadows the builtin \"minikube %s\" command, ignored\n", p.Path, p.Name)
+				continue
+			}
+			out.String("%s\t%s\n", p.Name, p.Path)
+		}
+	},
+}
+
+// isShadowed reports whether name collides with a builtin RootCmd subcommand.
+func isShadowed(name string) bool {
+	for _, c := range RootCmd.Commands() {
+		if c.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	PluginCmd.AddCommand(pluginListCmd)
+	RootCmd.AddCommand(PluginCmd)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config/profile"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// ProfileCmd manages named minikube cluster profiles.
+var ProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage minikube cluster profiles",
+	Long:  `profile lists, switches between, and deletes named minikube cluster profiles.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all cluster profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		names, err := profile.List()
+		if err != nil {
+			klog.Exitf("Error listing profiles: %v", err)
+		}
+		current := profile.Current()
+		if out.GetFormat() == out.JSON || out.GetFormat() == out.YAML {
+			if err := out.Data(names); err != nil {
+				klog.Exitf("Error printing profiles: %v", err)
+			}
+			return
+		}
+		// text and wide share the same simple "* name" rendering here: wide
+		// has no extra profile columns to add.
+		for _, n := range names {
+			if n == current {
+				out.String("* %s\n", n)
+				continue
+			}
+			out.String("  %s\n", n)
+		}
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use NAME",
+	Short: "Switch the active cluster profile",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			klog.Exitln("usage: minikube profile use NAME")
+		}
+		name := args[0]
+		if !profile.Exists(name) {
+			if err := profile.Create(name); err != nil {
+				klog.Exitf("Error creating profile %q: %v", name, err)
+			}
+		}
+		if err := profile.SetCurrent(name); err != nil {
+			klog.Exitf("Error switching to profile %q: %v", name, err)
+		}
+		out.String("Active profile is now %q\n", name)
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a cluster profile",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			klog.Exitln("usage: minikube profile delete NAME")
+		}
+		name := args[0]
+		if name == profile.Current() {
+			klog.Exitf("%q is the active profile, switch away from it before deleting", name)
+		}
+		if err := profile.Delete(name); err != nil {
+			klog.Exitf("Error deleting profile %q: %v", name, err)
+		}
+		out.String("Deleted profile %q\n", name)
+	},
+}
+
+func init() {
+	ProfileCmd.AddCommand(profileListCmd)
+	ProfileCmd.AddCommand(profileUseCmd)
+	ProfileCmd.AddCommand(profileDeleteCmd)
+	RootCmd.AddCommand(ProfileCmd)
+}
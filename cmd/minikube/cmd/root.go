@@ -24,39 +24,55 @@ import (
 	"strings"
 
 	"github.com/docker/machine/libmachine/log"
-	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
 	configCmd "k8s.io/minikube/cmd/minikube/cmd/config"
 	"k8s.io/minikube/cmd/util"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/config/profile"
 	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/logs"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/notify"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/plugin"
 )
 
-var dirs = [...]string{
-	constants.Minipath,
-	constants.MakeMiniPath("certs"),
-	constants.MakeMiniPath("machines"),
-	constants.MakeMiniPath("cache"),
-	constants.MakeMiniPath("cache", "iso"),
-	constants.MakeMiniPath("cache", "localkube"),
-	constants.MakeMiniPath("config"),
-	constants.MakeMiniPath("addons"),
-	constants.MakeMiniPath("logs"),
+// dirs returns the directories minikube needs to exist before running,
+// given the active profile. machines/, certs/ and logs/ live under that
+// profile; the rest (caches, addons) are shared across profiles.
+func dirs(profileName string) []string {
+	return []string{
+		constants.Minipath,
+		profile.Path(profileName, "certs"),
+		profile.Path(profileName, "machines"),
+		profile.Path(profileName, "logs"),
+		constants.MakeMiniPath("cache"),
+		constants.MakeMiniPath("cache", "iso"),
+		constants.MakeMiniPath("cache", "localkube"),
+		constants.MakeMiniPath("config"),
+		constants.MakeMiniPath("addons"),
+	}
 }
 
 const (
 	showLibmachineLogs = "show-libmachine-logs"
 	useVendoredDriver  = "use-vendored-driver"
+	outputFlag         = "output"
+	colorFlag          = "color"
+	profileFlag        = "profile"
+	logFormatFlag      = "log-format"
 )
 
 var (
 	enableUpdateNotification = true
 	enableKubectlDownloadMsg = true
 	clientType               machine.ClientType
+	// activeProfile is the cluster profile resolved for this invocation,
+	// from --profile, falling back to whatever `minikube profile use` last set.
+	activeProfile string
 )
 
 var viperWhiteList = []string{
@@ -71,14 +87,45 @@ var RootCmd = &cobra.Command{
 	Short: "Minikube is a tool for managing local Kubernetes clusters.",
 	Long:  `Minikube is a CLI tool that provisions and manages single-node Kubernetes clusters optimized for development workflows.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		for _, path := range dirs {
+		if err := out.SetFormat(viper.GetString(outputFlag)); err != nil {
+			klog.Exitln(err)
+		}
+		out.SetColor(shouldColorize(viper.GetString(colorFlag)))
+
+		if err := logs.SetFormat(viper.GetString(logFormatFlag)); err != nil {
+			klog.Exitln(err)
+		}
+		// setFlagsUsingViper (run via cobra.OnInitialize before this) has
+		// already resolved --v, so bridge its value into klog's own "v" flag.
+		if err := logs.SetVerbosity(viper.GetString("v")); err != nil {
+			klog.Exitln(err)
+		}
+
+		if err := profile.MigrateLegacyLayout(); err != nil {
+			klog.Exitf("Error migrating to profile layout: %v", err)
+		}
+
+		activeProfile = viper.GetString(profileFlag)
+		if activeProfile == "" {
+			activeProfile = profile.Current()
+		}
+		if !profile.Exists(activeProfile) {
+			if err := profile.Create(activeProfile); err != nil {
+				klog.Exitf("Error creating profile %q: %v", activeProfile, err)
+			}
+		}
+		if err := mergeProfileConfig(activeProfile); err != nil {
+			klog.Warningf("Error merging config for profile %q: %v", activeProfile, err)
+		}
+
+		for _, path := range dirs(activeProfile) {
 			if err := os.MkdirAll(path, 0777); err != nil {
-				glog.Exitf("Error creating minikube directory: %s", err)
+				klog.Exitf("Error creating minikube directory: %s", err)
 			}
 		}
 
 		if viper.GetBool(showLibmachineLogs) {
-			fmt.Println(`
+			out.Warning(`
 --show-libmachine-logs is deprecated.
 Please use --v=3 to show libmachine logs, and --v=7 for debug level libmachine logs
 `)
@@ -87,14 +134,20 @@ Please use --v=3 to show libmachine logs, and --v=7 for debug level libmachine l
 		//TODO(r2d4): config should not reference API
 		clientType = configCmd.GetClientType()
 
-		// Log level 3 or greater enables libmachine logs
-		if !glog.V(3) {
+		// Log level 3 or greater enables libmachine logs, routed through the
+		// same klog/logr sink as everything else so --log-format=json also
+		// covers libmachine's "vm-start" style events.
+		sink := logs.Sink()
+		if !klog.V(3).Enabled() {
 			log.SetOutWriter(ioutil.Discard)
 			log.SetErrWriter(ioutil.Discard)
+		} else {
+			log.SetOutWriter(logs.NewWriter(sink, "libmachine", false))
+			log.SetErrWriter(logs.NewWriter(sink, "libmachine", true))
 		}
 
 		// Log level 7 or greater enables debug level logs
-		if glog.V(7) {
+		if klog.V(7).Enabled() {
 			log.SetDebug(true)
 		}
 
@@ -107,15 +160,98 @@ Please use --v=3 to show libmachine logs, and --v=7 for debug level libmachine l
 	},
 }
 
+// shouldColorize resolves the --color flag ("auto", "always" or "never") to
+// a boolean, honoring NO_COLOR and falling back to TTY detection for "auto".
+// See https://no-color.org/.
+func shouldColorize(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	default:
+		if os.Getenv("NO_COLOR") != "" {
+			return false
+		}
+		fi, err := os.Stdout.Stat()
+		return err == nil && (fi.Mode()&os.ModeCharDevice) != 0
+	}
+}
+
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
-		glog.Exitln(err)
+		if strings.HasPrefix(err.Error(), "unknown command ") {
+			if name, args, ok := pluginArgs(); ok {
+				if p, found := plugin.Lookup(name); found {
+					// RootCmd.Execute stopped at the unknown command without
+					// parsing flags, so parse minikube's own leading flags
+					// (those pluginArgs skipped, e.g. --profile) here.
+					if err := RootCmd.PersistentFlags().Parse(os.Args[1 : len(os.Args)-len(args)-1]); err != nil {
+						klog.Exitf("Error parsing flags: %v", err)
+					}
+					activeProfile = viper.GetString(profileFlag)
+					if activeProfile == "" {
+						activeProfile = profile.Current()
+					}
+					os.Setenv("MINIKUBE_PROFILE", activeProfile)
+					if err := plugin.Exec(p, args); err != nil {
+						klog.Exitf("Error running plugin %q: %v", name, err)
+					}
+					return
+				}
+			}
+		}
+		klog.Exitln(err)
 	}
 }
 
-// Handle config values for flags used in external packages (e.g. glog)
+// pluginArgs walks os.Args just far enough to skip over minikube's own
+// persistent flags (so that "minikube --profile foo myplugin arg1" doesn't
+// shift the plugin name), then returns the first remaining token as the
+// plugin name and everything after it, sliced verbatim from os.Args, as
+// its args. It deliberately does not hand the tail off to a flag parser -
+// pflag's unknown-flag handling treats the token after an unrecognized
+// long flag as that flag's value and drops it, corrupting plugin argv.
+func pluginArgs() (name string, args []string, ok bool) {
+	rawArgs := os.Args[1:]
+	i := 0
+	for i < len(rawArgs) {
+		tok := rawArgs[i]
+		if !strings.HasPrefix(tok, "-") {
+			break
+		}
+
+		fname := strings.TrimLeft(tok, "-")
+		hasInlineValue := strings.Contains(fname, "=")
+		if hasInlineValue {
+			fname = fname[:strings.Index(fname, "=")]
+		}
+
+		f := RootCmd.PersistentFlags().Lookup(fname)
+		if f == nil && len(fname) == 1 {
+			f = RootCmd.PersistentFlags().ShorthandLookup(fname)
+		}
+		if f == nil {
+			// Not one of minikube's own flags, so this is where the
+			// plugin invocation itself starts.
+			break
+		}
+
+		i++
+		if !hasInlineValue && f.Value.Type() != "bool" {
+			i++ // the flag's value is the next, separate token
+		}
+	}
+
+	if i >= len(rawArgs) {
+		return "", nil, false
+	}
+	return rawArgs[i], rawArgs[i+1:], true
+}
+
+// Handle config values for flags used in external packages (e.g. klog)
 // by setting them directly, using values from viper when not passed in as args
 func setFlagsUsingViper() {
 	for _, config := range viperWhiteList {
@@ -135,6 +271,10 @@ func setFlagsUsingViper() {
 func init() {
 	RootCmd.PersistentFlags().Bool(showLibmachineLogs, false, "Deprecated: To enable libmachine logs, set --v=3 or higher")
 	RootCmd.PersistentFlags().Bool(useVendoredDriver, false, "Use the vendored in drivers instead of RPC")
+	RootCmd.PersistentFlags().StringP(outputFlag, "o", "text", fmt.Sprintf("Format to print stdout in. Options are %v", out.ValidFormats))
+	RootCmd.PersistentFlags().String(colorFlag, "auto", "Colorize output. Options are auto, always, never")
+	RootCmd.PersistentFlags().StringP(profileFlag, "p", "", fmt.Sprintf("The cluster profile to use, defaults to %q or whatever 'minikube profile use' last set", profile.DefaultName))
+	RootCmd.PersistentFlags().String(logFormatFlag, "text", fmt.Sprintf("Log format to use. Options are %v", logs.ValidFormats))
 	RootCmd.AddCommand(configCmd.ConfigCmd)
 	RootCmd.AddCommand(configCmd.AddonsCmd)
 	pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
@@ -153,11 +293,32 @@ func initConfig() {
 	viper.SetConfigType("json")
 	err := viper.ReadInConfig()
 	if err != nil {
-		glog.Warningf("Error reading config file at %s: %s", configPath, err)
+		klog.Warningf("Error reading config file at %s: %s", configPath, err)
 	}
+
+	if endpoint := os.Getenv("MINIKUBE_REMOTE_CONFIG"); endpoint != "" {
+		provider := os.Getenv("MINIKUBE_REMOTE_CONFIG_PROVIDER")
+		if provider == "" {
+			provider = "etcd"
+		}
+		if err := viper.AddRemoteProvider(provider, endpoint, constants.ConfigFile); err != nil {
+			klog.Warningf("Error adding remote config provider %s at %s: %v", provider, endpoint, err)
+		} else if err := viper.ReadRemoteConfig(); err != nil {
+			klog.Warningf("Error reading remote config from %s at %s: %v", provider, endpoint, err)
+		}
+	}
+
 	setupViper()
 }
 
+// mergeProfileConfig layers the named profile's config.json on top of the
+// global config already loaded by initConfig, so that per-profile settings
+// (e.g. kubernetes-version) take precedence over the global defaults.
+func mergeProfileConfig(name string) error {
+	viper.SetConfigFile(profile.ConfigPath(name))
+	return viper.MergeInConfig()
+}
+
 func setupViper() {
 	viper.SetEnvPrefix(constants.MinikubeEnvPrefix)
 	// Replaces '-' in flags with '_' in env variables
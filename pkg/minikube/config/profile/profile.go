@@ -0,0 +1,200 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package profile manages named minikube cluster profiles. Each profile
+// owns its own machines/, certs/ and logs/ directories and its own
+// config.json, all rooted under ~/.minikube/profiles/<name>, so that
+// multiple clusters can coexist on one host.
+package profile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// DefaultName is the profile used when --profile is not given.
+const DefaultName = "minikube"
+
+// profilesDirName is the directory, relative to constants.Minipath, that
+// holds all profiles.
+const profilesDirName = "profiles"
+
+// activeFileName records which profile `minikube profile use` last
+// selected. It is deliberately its own file, sitting next to the profiles
+// rather than inside the shared global config.json: writing it through
+// viper would serialize the whole merged config state (global settings
+// plus whatever profile config happened to be merged in for this
+// invocation) back over the global file, leaking one profile's settings
+// into every other one.
+const activeFileName = "active"
+
+// legacyDirs are the per-cluster directories that used to live directly
+// under ~/.minikube before profiles existed.
+var legacyDirs = []string{"machines", "certs", "logs"}
+
+// ProfilesDir returns ~/.minikube/profiles.
+func ProfilesDir() string {
+	return constants.MakeMiniPath(profilesDirName)
+}
+
+// Dir returns the root directory for the named profile.
+func Dir(name string) string {
+	return filepath.Join(ProfilesDir(), name)
+}
+
+// Path joins elem onto the named profile's directory, mirroring
+// constants.MakeMiniPath but scoped to a single profile.
+func Path(name string, elem ...string) string {
+	return filepath.Join(append([]string{Dir(name)}, elem...)...)
+}
+
+// ConfigPath returns the path to the named profile's config.json.
+func ConfigPath(name string) string {
+	return Path(name, "config.json")
+}
+
+// activePath returns the path to the file recording the active profile.
+func activePath() string {
+	return filepath.Join(ProfilesDir(), activeFileName)
+}
+
+// Current returns the active profile name: whatever was last selected with
+// `minikube profile use`, or DefaultName if none has been.
+func Current() string {
+	b, err := ioutil.ReadFile(activePath())
+	if err != nil {
+		return DefaultName
+	}
+	name := strings.TrimSpace(string(b))
+	if name == "" {
+		return DefaultName
+	}
+	return name
+}
+
+// SetCurrent persists name as the active profile.
+func SetCurrent(name string) error {
+	if !Exists(name) {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	if err := os.MkdirAll(ProfilesDir(), 0777); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(activePath(), []byte(name), 0644)
+}
+
+// Exists reports whether the named profile has been created.
+func Exists(name string) bool {
+	_, err := os.Stat(Dir(name))
+	return err == nil
+}
+
+// List returns the names of all existing profiles.
+func List() ([]string, error) {
+	entries, err := ioutil.ReadDir(ProfilesDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// Create makes the on-disk layout for a new profile: machines/, certs/,
+// logs/ and an empty config.json.
+func Create(name string) error {
+	if Exists(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	for _, d := range legacyDirs {
+		if err := os.MkdirAll(Path(name, d), 0777); err != nil {
+			return fmt.Errorf("creating %s for profile %q: %v", d, name, err)
+		}
+	}
+	if _, err := os.Stat(ConfigPath(name)); os.IsNotExist(err) {
+		if err := ioutil.WriteFile(ConfigPath(name), []byte("{}"), 0644); err != nil {
+			return fmt.Errorf("writing config for profile %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Delete removes a profile and everything under it.
+func Delete(name string) error {
+	if !Exists(name) {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	return os.RemoveAll(Dir(name))
+}
+
+// MigrateLegacyLayout moves a pre-profile ~/.minikube layout (machines/,
+// certs/ and logs/ sitting directly under ~/.minikube, plus the global
+// config.json at constants.ConfigFile) into
+// the DefaultName profile, the first time profiles are used on a host
+// that already had a single cluster.
+func MigrateLegacyLayout() error {
+	if Exists(DefaultName) {
+		return nil
+	}
+
+	var found bool
+	for _, d := range legacyDirs {
+		if _, err := os.Stat(constants.MakeMiniPath(d)); err == nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	if err := os.MkdirAll(Dir(DefaultName), 0777); err != nil {
+		return err
+	}
+	for _, d := range legacyDirs {
+		src := constants.MakeMiniPath(d)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if err := os.Rename(src, Path(DefaultName, d)); err != nil {
+			return fmt.Errorf("migrating %s into profile %q: %v", d, DefaultName, err)
+		}
+	}
+	if _, err := os.Stat(constants.ConfigFile); err == nil {
+		if err := os.Rename(constants.ConfigFile, ConfigPath(DefaultName)); err != nil {
+			return fmt.Errorf("migrating config.json into profile %q: %v", DefaultName, err)
+		}
+		// initConfig always expects a global config.json to read, even if
+		// it ends up contributing nothing once the profile's own config is
+		// merged on top; recreate an empty one so it doesn't warn forever.
+		if err := ioutil.WriteFile(constants.ConfigFile, []byte("{}"), 0644); err != nil {
+			return fmt.Errorf("recreating global config after migration: %v", err)
+		}
+	}
+	return nil
+}
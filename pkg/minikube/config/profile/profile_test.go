@@ -0,0 +1,177 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// withMinipath points constants.Minipath at a fresh temp directory for the
+// duration of a test, so profile operations never touch the real ~/.minikube.
+func withMinipath(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "profile-test")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	old := constants.Minipath
+	oldConfig := constants.ConfigFile
+	constants.Minipath = dir
+	// constants.ConfigFile does not follow Minipath, so redirect it too.
+	constants.ConfigFile = filepath.Join(dir, "config", "config.json")
+	if err := os.MkdirAll(filepath.Dir(constants.ConfigFile), 0777); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	t.Cleanup(func() {
+		constants.Minipath = old
+		constants.ConfigFile = oldConfig
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+func TestCreateExistsDelete(t *testing.T) {
+	withMinipath(t)
+
+	if Exists("p1") {
+		t.Fatal("profile p1 should not exist yet")
+	}
+	if err := Create("p1"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !Exists("p1") {
+		t.Fatal("profile p1 should exist after Create")
+	}
+	for _, d := range []string{"machines", "certs", "logs"} {
+		if _, err := os.Stat(Path("p1", d)); err != nil {
+			t.Errorf("expected %s to exist: %v", d, err)
+		}
+	}
+	if _, err := os.Stat(ConfigPath("p1")); err != nil {
+		t.Errorf("expected config.json to exist: %v", err)
+	}
+
+	if err := Create("p1"); err == nil {
+		t.Fatal("expected Create to fail for an already-existing profile")
+	}
+
+	if err := Delete("p1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if Exists("p1") {
+		t.Fatal("profile p1 should not exist after Delete")
+	}
+	if err := Delete("p1"); err == nil {
+		t.Fatal("expected Delete to fail for a nonexistent profile")
+	}
+}
+
+func TestList(t *testing.T) {
+	withMinipath(t)
+
+	names, err := List()
+	if err != nil {
+		t.Fatalf("List on a fresh Minipath: %v", err)
+	}
+	if len(names) != 0 {
+		t.Fatalf("expected no profiles, got %v", names)
+	}
+
+	for _, n := range []string{"a", "b"} {
+		if err := Create(n); err != nil {
+			t.Fatalf("Create(%q): %v", n, err)
+		}
+	}
+	names, err = List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected 2 profiles, got %v", names)
+	}
+}
+
+func TestCurrentAndSetCurrent(t *testing.T) {
+	withMinipath(t)
+
+	if got := Current(); got != DefaultName {
+		t.Fatalf("Current with nothing set = %q, want %q", got, DefaultName)
+	}
+
+	if err := SetCurrent("does-not-exist"); err == nil {
+		t.Fatal("expected SetCurrent to fail for a nonexistent profile")
+	}
+
+	if err := Create("other"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := SetCurrent("other"); err != nil {
+		t.Fatalf("SetCurrent: %v", err)
+	}
+	if got := Current(); got != "other" {
+		t.Fatalf("Current = %q, want %q", got, "other")
+	}
+
+	// SetCurrent must only ever touch its own file, never the shared
+	// global config.json.
+	if _, err := os.Stat(constants.ConfigFile); err == nil {
+		t.Fatal("SetCurrent should not have created/touched the global config.json")
+	}
+}
+
+func TestMigrateLegacyLayout(t *testing.T) {
+	dir := withMinipath(t)
+
+	// Nothing to migrate.
+	if err := MigrateLegacyLayout(); err != nil {
+		t.Fatalf("MigrateLegacyLayout with nothing to migrate: %v", err)
+	}
+	if Exists(DefaultName) {
+		t.Fatal("MigrateLegacyLayout should be a no-op without a legacy layout")
+	}
+
+	// Lay out a pre-profile ~/.minikube.
+	if err := os.MkdirAll(filepath.Join(dir, "machines"), 0777); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := ioutil.WriteFile(constants.ConfigFile, []byte(`{"foo":"bar"}`), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := MigrateLegacyLayout(); err != nil {
+		t.Fatalf("MigrateLegacyLayout: %v", err)
+	}
+	if !Exists(DefaultName) {
+		t.Fatal("expected legacy layout to be migrated into the default profile")
+	}
+	if _, err := os.Stat(Path(DefaultName, "machines")); err != nil {
+		t.Errorf("expected machines/ under the default profile: %v", err)
+	}
+	if _, err := os.Stat(ConfigPath(DefaultName)); err != nil {
+		t.Errorf("expected config.json under the default profile: %v", err)
+	}
+	// The global config.json must still exist afterwards so initConfig
+	// doesn't warn on every later command.
+	if _, err := os.Stat(constants.ConfigFile); err != nil {
+		t.Errorf("expected global config.json to be recreated: %v", err)
+	}
+}
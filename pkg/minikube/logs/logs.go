@@ -0,0 +1,104 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logs is minikube's single entry point onto klog/logr. It lets
+// every component - including libmachine, which brings its own writer-based
+// logger - emit through the same sink, so that --log-format=json produces
+// coherent structured records instead of a mix of glog lines and raw
+// libmachine text.
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/klogr"
+)
+
+// ValidFormats are the formats accepted by --log-format.
+var ValidFormats = []string{"text", "json"}
+
+func init() {
+	// Register klog's flags (v, log_dir, alsologtostderr, ...) onto the
+	// global flag.CommandLine, exactly where glog used to put them via its
+	// own package init(). root.go then bridges flag.CommandLine into pflag
+	// with pflag.CommandLine.AddGoFlagSet, so a private FlagSet here would
+	// leave those flags unregistered and pflag.Lookup would return nil.
+	klog.InitFlags(flag.CommandLine)
+}
+
+// SetFormat switches klog's output between plain text and structured JSON
+// records. Must be called before any logging happens.
+func SetFormat(format string) error {
+	switch format {
+	case "", "text":
+		return nil
+	case "json":
+		return flag.CommandLine.Set("logging-format", "json")
+	default:
+		return fmt.Errorf("unknown log format %q, must be one of %v", format, ValidFormats)
+	}
+}
+
+// SetVerbosity bridges the resolved --v value into klog's own verbosity
+// flag.
+func SetVerbosity(v string) error {
+	if v == "" {
+		return nil
+	}
+	return flag.CommandLine.Set("v", v)
+}
+
+// Sink returns the logr.Logger that all minikube components, including
+// non-klog-aware libraries wired through NewWriter, should log through.
+func Sink() logr.Logger {
+	return klogr.New()
+}
+
+// writer adapts a logr.Logger to the io.Writer interface expected by
+// libmachine's log package, so libmachine's events show up as records
+// from the same sink instead of bypassing it.
+type writer struct {
+	log   logr.Logger
+	isErr bool
+}
+
+// NewWriter returns an io.Writer that logs each line written to it through
+// sink, tagged with name (e.g. "libmachine"). Use isErr for writers that
+// only ever carry error output.
+func NewWriter(sink logr.Logger, name string, isErr bool) *writer {
+	return &writer{log: sink.WithName(name), isErr: isErr}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(p))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if w.isErr {
+			w.log.Error(nil, line)
+		} else {
+			w.log.Info(line)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package out is the only place that minikube subcommands should print
+// user-facing output. Funneling prints through here lets us support
+// multiple output formats (text, json, yaml, wide) and colorization
+// from a single place, instead of every command deciding on its own.
+package out
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v2"
+)
+
+// Format is the requested output format for user-facing prints.
+type Format string
+
+const (
+	// Text is the default, human readable output format.
+	Text Format = "text"
+	// JSON renders structured data as JSON.
+	JSON Format = "json"
+	// YAML renders structured data as YAML.
+	YAML Format = "yaml"
+	// Wide is a text format with additional columns, left to each
+	// command to interpret.
+	Wide Format = "wide"
+)
+
+var (
+	// outFormat is the active output format, set via SetFormat.
+	outFormat = Text
+
+	// stdout/stderr are overridable for tests.
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// ValidFormats are the output formats accepted by --output.
+var ValidFormats = []string{string(Text), string(JSON), string(YAML), string(Wide)}
+
+// SetFormat sets the active output format, returning an error if f is not
+// one of ValidFormats.
+func SetFormat(f string) error {
+	switch Format(f) {
+	case Text, JSON, YAML, Wide:
+		outFormat = Format(f)
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of %v", f, ValidFormats)
+	}
+}
+
+// GetFormat returns the active output format.
+func GetFormat() Format {
+	return outFormat
+}
+
+// SetColor enables or disables ANSI colorization of subsequent output.
+func SetColor(enabled bool) {
+	color.NoColor = !enabled
+}
+
+// String prints a plain (uncolored), printf-style message to stdout. It is a no-op
+// when the active format is JSON or YAML, since those formats must only
+// ever contain the serialized data from Data.
+func String(format string, a ...interface{}) {
+	raw(fmt.Sprintf(format, a...))
+}
+
+// raw writes an already-rendered string to stdout, honoring the same
+// JSON/YAML gating as String, without re-running it through Fprintf (which
+// would reinterpret any literal '%' left in s as a format verb).
+func raw(s string) {
+	if outFormat == JSON || outFormat == YAML {
+		return
+	}
+	fmt.Fprint(stdout, s)
+}
+
+// Err prints a colorized, printf-style message to stderr. Err is always
+// written, regardless of output format, since it is never part of
+// machine-parsed output.
+func Err(format string, a ...interface{}) {
+	fmt.Fprint(stderr, color.RedString(format, a...))
+}
+
+// Warning prints a colorized warning to stderr.
+func Warning(format string, a ...interface{}) {
+	fmt.Fprint(stderr, color.YellowString(format, a...))
+}
+
+// Success prints a colorized success message to stdout.
+func Success(format string, a ...interface{}) {
+	raw(color.GreenString(format, a...))
+}
+
+// Data prints v to stdout using the active output format. Commands that
+// produce structured results (status, ip, service, addons list, ...)
+// should call Data instead of String so that -o json/yaml works uniformly.
+func Data(v interface{}) error {
+	switch outFormat {
+	case JSON:
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case YAML:
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = stdout.Write(b)
+		return err
+	default:
+		// text and wide are rendered by the caller, who knows the shape
+		// of v and which columns "wide" should add.
+		return fmt.Errorf("Data only supports json/yaml formats, got %s", outFormat)
+	}
+}
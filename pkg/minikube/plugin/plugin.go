@@ -0,0 +1,107 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin discovers and runs external minikube subcommands,
+// mirroring kubectl's plugin model: any executable named "minikube-foo"
+// found on $PATH can be invoked as "minikube foo".
+package plugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// prefix all plugin binaries must start with.
+const prefix = "minikube-"
+
+// Plugin describes a discovered plugin binary.
+type Plugin struct {
+	// Name is the subcommand used to invoke it, e.g. "foo" for "minikube-foo".
+	Name string
+	// Path is the absolute path to the plugin binary.
+	Path string
+}
+
+// forwardedEnv are the environment variables passed through to a plugin
+// verbatim, on top of the rest of the parent's environment.
+var forwardedEnv = []string{"MINIKUBE_PROFILE", "KUBECONFIG", "MINIKUBE_HOME"}
+
+// Discover scans $PATH for executables named "minikube-*" and returns the
+// plugins they implement. When the same plugin name appears in more than
+// one $PATH directory, the first one found (in $PATH order) wins, matching
+// shell lookup semantics.
+func Discover() ([]Plugin, error) {
+	seen := map[string]bool{}
+	var found []Plugin
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, info := range entries {
+			name := info.Name()
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			if info.IsDir() || info.Mode()&0111 == 0 {
+				continue
+			}
+			cmdName := strings.TrimPrefix(name, prefix)
+			if cmdName == "" || seen[cmdName] {
+				continue
+			}
+			seen[cmdName] = true
+			found = append(found, Plugin{Name: cmdName, Path: filepath.Join(dir, name)})
+		}
+	}
+	return found, nil
+}
+
+// Lookup returns the plugin that implements "minikube <name>", if any.
+func Lookup(name string) (Plugin, bool) {
+	plugins, err := Discover()
+	if err != nil {
+		return Plugin{}, false
+	}
+	for _, p := range plugins {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// Exec replaces the current process with the plugin binary, forwarding
+// args and the environment variables in forwardedEnv on top of the
+// parent's own environment - the same contract kubectl plugins rely on.
+func Exec(p Plugin, args []string) error {
+	env := os.Environ()
+	for _, k := range forwardedEnv {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+	argv := append([]string{p.Path}, args...)
+	return syscall.Exec(p.Path, argv, env)
+}
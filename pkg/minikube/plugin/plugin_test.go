@@ -0,0 +1,123 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// writeExecutable creates an executable file at dir/name.
+func writeExecutable(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin discovery relies on unix executable bits")
+	}
+
+	first, err := ioutil.TempDir("", "plugin-test-first")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(first)
+	second, err := ioutil.TempDir("", "plugin-test-second")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(second)
+
+	writeExecutable(t, first, "minikube-foo")
+	writeExecutable(t, first, "minikube-shared")
+	writeExecutable(t, second, "minikube-shared")
+	writeExecutable(t, second, "minikube-bar")
+	// Not a plugin: wrong prefix.
+	writeExecutable(t, first, "notaplugin")
+	// Not a plugin: not executable.
+	if err := ioutil.WriteFile(filepath.Join(second, "minikube-noexec"), []byte(""), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	oldPath := os.Getenv("PATH")
+	defer os.Setenv("PATH", oldPath)
+	os.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	plugins, err := Discover()
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	byName := map[string]Plugin{}
+	for _, p := range plugins {
+		byName[p.Name] = p
+	}
+
+	if _, ok := byName["foo"]; !ok {
+		t.Error("expected to discover minikube-foo as plugin \"foo\"")
+	}
+	if _, ok := byName["bar"]; !ok {
+		t.Error("expected to discover minikube-bar as plugin \"bar\"")
+	}
+	if _, ok := byName["noexec"]; ok {
+		t.Error("non-executable minikube-noexec should not be discovered")
+	}
+	if _, ok := byName["notaplugin"]; ok {
+		t.Error("notaplugin should not be discovered")
+	}
+
+	// $PATH order wins when the same plugin name appears twice.
+	shared, ok := byName["shared"]
+	if !ok {
+		t.Fatal("expected to discover minikube-shared as plugin \"shared\"")
+	}
+	if want := filepath.Join(first, "minikube-shared"); shared.Path != want {
+		t.Errorf("shared.Path = %q, want the earlier $PATH entry %q", shared.Path, want)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin discovery relies on unix executable bits")
+	}
+
+	dir, err := ioutil.TempDir("", "plugin-test-lookup")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	writeExecutable(t, dir, "minikube-foo")
+
+	oldPath := os.Getenv("PATH")
+	defer os.Setenv("PATH", oldPath)
+	os.Setenv("PATH", dir)
+
+	if _, ok := Lookup("foo"); !ok {
+		t.Error("expected Lookup(\"foo\") to find minikube-foo")
+	}
+	if _, ok := Lookup("nope"); ok {
+		t.Error("expected Lookup(\"nope\") to find nothing")
+	}
+}